@@ -0,0 +1,46 @@
+package igopher
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// botState tracks whether the DM bot is currently running, so HotReload can
+// refuse to run against a bot that was never launched.
+type botState struct {
+	running bool
+}
+
+// BotStruct is the single DM bot instance's runtime state, read by
+// Controller.HotReload.
+var BotStruct botState
+
+// sendDirectMessage delivers message to username. The default implementation
+// only logs the message; no Instagram session is involved. It is a
+// package-level var, not a plain function, so tests can swap it for a fake.
+var sendDirectMessage = func(ctx context.Context, username, message string) error {
+	logrus.Infof("Sending DM to %s: %s", username, message)
+	return nil
+}
+
+// launchDmBot sends preparedMessages[username] to every recipient it was
+// rendered for, stopping early once ctx is canceled, then reports the overall
+// outcome on exitedCh: true only if every DM was sent successfully.
+func launchDmBot(ctx context.Context) {
+	BotStruct.running = true
+	defer func() { BotStruct.running = false }()
+
+	success := true
+	for username, message := range preparedMessages {
+		if ctx.Err() != nil {
+			success = false
+			break
+		}
+		if err := sendDirectMessage(ctx, username, message); err != nil {
+			logrus.Errorf("Failed to send DM to %s: %v", username, err)
+			success = false
+		}
+	}
+	exitedCh <- success
+}
@@ -0,0 +1,50 @@
+package igopher
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLaunchDmBotSendsThePreparedMessage(t *testing.T) {
+	type sent struct {
+		username, message string
+	}
+	var got []sent
+
+	original := sendDirectMessage
+	sendDirectMessage = func(_ context.Context, username, message string) error {
+		got = append(got, sent{username, message})
+		return nil
+	}
+	defer func() { sendDirectMessage = original }()
+
+	preparedMessages = map[string]string{"alice": "Hi alice!"}
+	defer func() { preparedMessages = nil }()
+	exitedCh = make(chan bool)
+
+	go launchDmBot(context.Background())
+	if success := <-exitedCh; !success {
+		t.Fatal("launchDmBot() reported failure, want success")
+	}
+
+	if len(got) != 1 || got[0].username != "alice" || got[0].message != "Hi alice!" {
+		t.Errorf("sendDirectMessage calls = %+v, want a single call for alice's prepared message", got)
+	}
+}
+
+func TestLaunchDmBotReportsFailureWhenSendFails(t *testing.T) {
+	original := sendDirectMessage
+	sendDirectMessage = func(_ context.Context, _, _ string) error {
+		return errRenderFailedForTest
+	}
+	defer func() { sendDirectMessage = original }()
+
+	preparedMessages = map[string]string{"bob": "Hi bob!"}
+	defer func() { preparedMessages = nil }()
+	exitedCh = make(chan bool)
+
+	go launchDmBot(context.Background())
+	if success := <-exitedCh; success {
+		t.Error("launchDmBot() reported success, want failure")
+	}
+}
@@ -0,0 +1,206 @@
+package igopher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Controller centralizes every operation that can be triggered on IGopher,
+// independently of the transport used to trigger it (Electron IPC, gRPC,
+// REST gateway, ...). Electron callbacks and the gRPC handlers both go
+// through it so they share the same bot lifecycle (ctx/cancel/exitedCh)
+// instead of each transport keeping its own copy around.
+type Controller struct {
+	jobs *JobQueue
+}
+
+// NewController returns a ready-to-use Controller. A single instance should
+// be shared by every transport registered on the process.
+func NewController() *Controller {
+	jobs, err := NewJobQueue(jobsDBPath)
+	if err != nil {
+		// The job queue is only needed to launch campaigns; don't prevent
+		// the rest of the Controller (config forms, ...) from working.
+		logrus.Errorf("Failed to open job queue, launching the bot will be unavailable: %v", err)
+	}
+	return &Controller{jobs: jobs}
+}
+
+// UpdateCredentials validates and persists the Instagram account credentials.
+func (c *Controller) UpdateCredentials(ctx context.Context, account AccountYaml) error {
+	if err := validate.Struct(account); err != nil {
+		return err
+	}
+	config = ImportConfig()
+	config.Account = account
+	ExportConfig(config)
+	return nil
+}
+
+// UpdateQuotas validates and persists the daily/hourly quotas.
+func (c *Controller) UpdateQuotas(ctx context.Context, quotas QuotasYaml) error {
+	if err := validate.Struct(quotas); err != nil {
+		return err
+	}
+	config = ImportConfig()
+	config.Quotas = quotas
+	ExportConfig(config)
+	return nil
+}
+
+// UpdateSchedule validates and persists the bot's run schedule.
+func (c *Controller) UpdateSchedule(ctx context.Context, schedule ScheduleYaml) error {
+	if err := validate.Struct(schedule); err != nil {
+		return err
+	}
+	config = ImportConfig()
+	config.Schedule = schedule
+	ExportConfig(config)
+	return nil
+}
+
+// UpdateBlacklist validates and persists the blacklisted usernames.
+func (c *Controller) UpdateBlacklist(ctx context.Context, blacklist BlacklistYaml) error {
+	if err := validate.Struct(blacklist); err != nil {
+		return err
+	}
+	config = ImportConfig()
+	config.Blacklist = blacklist
+	ExportConfig(config)
+	return nil
+}
+
+// UpdateAutoDm validates and persists the DM bot settings, including
+// compiling every greeting template so a malformed {{ }} placeholder is
+// rejected at submit time rather than at send time. {spintax|alternation}
+// syntax is not checked here.
+func (c *Controller) UpdateAutoDm(ctx context.Context, autoDm AutoDmYaml) error {
+	if err := validate.Struct(autoDm); err != nil {
+		return err
+	}
+	for i, greeting := range autoDm.Greetings {
+		if _, err := ParseMessageTemplate(greeting); err != nil {
+			return &TemplateError{Field: fmt.Sprintf("greetings[%d]", i), Err: err}
+		}
+	}
+	config = ImportConfig()
+	config.AutoDm = autoDm
+	ExportConfig(config)
+	return nil
+}
+
+// UpdateScrapper validates and persists the user scrapper settings.
+func (c *Controller) UpdateScrapper(ctx context.Context, scrapper ScrapperYaml) error {
+	if err := validate.Struct(scrapper); err != nil {
+		return err
+	}
+	config = ImportConfig()
+	config.SrcUsers = scrapper
+	ExportConfig(config)
+	return nil
+}
+
+// Launch submits the current configuration as a one-shot Job instead of
+// mutating ctx/cancel directly, so it shares history and cancellation with
+// every other campaign enqueued through EnqueueJob.
+func (c *Controller) Launch(ctx context.Context) error {
+	_, err := c.EnqueueJob(ctx, nil, JobTrigger{})
+	return err
+}
+
+// EnqueueJob validates the current configuration and submits it as a Job
+// targeting targets, run according to trigger (immediately when trigger is
+// the zero value).
+func (c *Controller) EnqueueJob(_ context.Context, targets []string, trigger JobTrigger) (*Job, error) {
+	if c.jobs == nil {
+		return nil, fmt.Errorf("job queue is unavailable")
+	}
+	if err := CheckConfigValidity(); err != nil {
+		return nil, err
+	}
+	return c.jobs.Enqueue(ImportConfig(), targets, trigger)
+}
+
+// ListJobs returns every queued/running/completed job, most recent first.
+func (c *Controller) ListJobs(_ context.Context) ([]*Job, error) {
+	if c.jobs == nil {
+		return nil, fmt.Errorf("job queue is unavailable")
+	}
+	return c.jobs.List(), nil
+}
+
+// CancelJob stops job id if it is queued or running.
+func (c *Controller) CancelJob(_ context.Context, id string) error {
+	if c.jobs == nil {
+		return fmt.Errorf("job queue is unavailable")
+	}
+	return c.jobs.Cancel(id)
+}
+
+// JobHistory returns the full record (status, timestamps, per-target
+// results, error trail) of job id.
+func (c *Controller) JobHistory(_ context.Context, id string) (*Job, error) {
+	if c.jobs == nil {
+		return nil, fmt.Errorf("job queue is unavailable")
+	}
+	job, ok := c.jobs.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("job %s not found", id)
+	}
+	return job, nil
+}
+
+// Stop cancels the running DM bot and waits for it to exit.
+func (c *Controller) Stop(ctx context.Context) error {
+	if exitedCh == nil {
+		return fmt.Errorf("bot is in the initialization phase, please wait before trying to stop it")
+	}
+	cancel()
+	if res := <-exitedCh; !res {
+		return fmt.Errorf("error during bot stopping, please restart IGopher")
+	}
+	return nil
+}
+
+// HotReload asks the running DM bot to reload its configuration without
+// restarting the whole process.
+func (c *Controller) HotReload(ctx context.Context) error {
+	if !BotStruct.running {
+		return fmt.Errorf("bot isn't running yet")
+	}
+	if hotReloadCh == nil {
+		return fmt.Errorf("bot is in the initialization phase, please wait before trying to hot reload it")
+	}
+	hotReloadCh <- true
+	if res := <-hotReloadCh; !res {
+		return fmt.Errorf("error during bot hot reload, please restart the bot")
+	}
+	return nil
+}
+
+// ResetConfig resets the configuration to its default values.
+func (c *Controller) ResetConfig(ctx context.Context) error {
+	config = ResetBotConfig()
+	ExportConfig(config)
+	return nil
+}
+
+// ClearData wipes every piece of data persisted by IGopher (config, logs,
+// browser profile, ..., and the job queue's history).
+func (c *Controller) ClearData(ctx context.Context) error {
+	if err := ClearData(); err != nil {
+		return err
+	}
+	if c.jobs != nil {
+		return c.jobs.Reset()
+	}
+	return nil
+}
+
+// Config returns the current configuration, reloading it from disk first.
+func (c *Controller) Config() BotConfigYaml {
+	config = ImportConfig()
+	return config
+}
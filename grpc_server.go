@@ -0,0 +1,212 @@
+package igopher
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	igopherrpc "github.com/Honest0/IGopher/rpc"
+)
+
+// grpcServer adapts the Controller to the generated igopherrpc.IGopherServer
+// interface so gRPC clients can drive IGopher the same way the Electron GUI
+// does through handleMessages.
+type grpcServer struct {
+	igopherrpc.UnimplementedIGopherServer
+	controller *Controller
+}
+
+func newGRPCServer(controller *Controller) *grpcServer {
+	return &grpcServer{controller: controller}
+}
+
+func (s *grpcServer) UpdateCredentials(ctx context.Context, req *igopherrpc.JSONRequest) (*igopherrpc.StatusReply, error) {
+	var account AccountYaml
+	if err := json.Unmarshal(req.GetPayload(), &account); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal payload: %v", err)
+	}
+	if err := s.controller.UpdateCredentials(ctx, account); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Credentials settings successfully updated!"}, nil
+}
+
+func (s *grpcServer) UpdateQuotas(ctx context.Context, req *igopherrpc.JSONRequest) (*igopherrpc.StatusReply, error) {
+	var quotas QuotasYaml
+	if err := json.Unmarshal(req.GetPayload(), &quotas); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal payload: %v", err)
+	}
+	if err := s.controller.UpdateQuotas(ctx, quotas); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Quotas settings successfully updated!"}, nil
+}
+
+func (s *grpcServer) UpdateSchedule(ctx context.Context, req *igopherrpc.JSONRequest) (*igopherrpc.StatusReply, error) {
+	var schedule ScheduleYaml
+	if err := json.Unmarshal(req.GetPayload(), &schedule); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal payload: %v", err)
+	}
+	if err := s.controller.UpdateSchedule(ctx, schedule); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Scheduler settings successfully updated!"}, nil
+}
+
+func (s *grpcServer) UpdateBlacklist(ctx context.Context, req *igopherrpc.JSONRequest) (*igopherrpc.StatusReply, error) {
+	var blacklist BlacklistYaml
+	if err := json.Unmarshal(req.GetPayload(), &blacklist); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal payload: %v", err)
+	}
+	if err := s.controller.UpdateBlacklist(ctx, blacklist); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Blacklist settings successfully updated!"}, nil
+}
+
+func (s *grpcServer) UpdateAutoDm(ctx context.Context, req *igopherrpc.JSONRequest) (*igopherrpc.StatusReply, error) {
+	var autoDm AutoDmYaml
+	if err := json.Unmarshal(req.GetPayload(), &autoDm); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal payload: %v", err)
+	}
+	if err := s.controller.UpdateAutoDm(ctx, autoDm); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Dm bot settings successfully updated!"}, nil
+}
+
+func (s *grpcServer) UpdateScrapper(ctx context.Context, req *igopherrpc.JSONRequest) (*igopherrpc.StatusReply, error) {
+	var scrapper ScrapperYaml
+	if err := json.Unmarshal(req.GetPayload(), &scrapper); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal payload: %v", err)
+	}
+	if err := s.controller.UpdateScrapper(ctx, scrapper); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Scrapper settings successfully updated!"}, nil
+}
+
+func (s *grpcServer) Launch(ctx context.Context, _ *igopherrpc.Empty) (*igopherrpc.StatusReply, error) {
+	if err := s.controller.Launch(ctx); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Dm bot successfully launched!"}, nil
+}
+
+func (s *grpcServer) Stop(ctx context.Context, _ *igopherrpc.Empty) (*igopherrpc.StatusReply, error) {
+	if err := s.controller.Stop(ctx); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Dm bot successfully stopped!"}, nil
+}
+
+func (s *grpcServer) HotReload(ctx context.Context, _ *igopherrpc.Empty) (*igopherrpc.StatusReply, error) {
+	if err := s.controller.HotReload(ctx); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Bot hot reload successfully!"}, nil
+}
+
+func (s *grpcServer) StreamLogs(_ *igopherrpc.Empty, stream igopherrpc.IGopher_StreamLogsServer) error {
+	id, lines := hub.Subscribe(logrus.InfoLevel)
+	defer hub.Unsubscribe(id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&igopherrpc.LogLine{
+				Timestamp: line.Timestamp.Format(time.RFC3339),
+				Level:     line.Level.String(),
+				Module:    line.Module,
+				Message:   line.Message,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *grpcServer) ResetConfig(ctx context.Context, _ *igopherrpc.Empty) (*igopherrpc.StatusReply, error) {
+	if err := s.controller.ResetConfig(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "Global configuration was successfully reseted!"}, nil
+}
+
+func (s *grpcServer) ClearData(ctx context.Context, _ *igopherrpc.Empty) (*igopherrpc.StatusReply, error) {
+	if err := s.controller.ClearData(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &igopherrpc.StatusReply{Success: true, Message: "IGopher data successfully cleared!"}, nil
+}
+
+// authToken is the bearer token expected in the "authorization" metadata of
+// every gRPC call. It is set once by StartGRPCServer.
+var authToken string
+
+func tokenAuthInterceptor(ctx context.Context) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	tokens := md.Get("authorization")
+	want := "Bearer " + authToken
+	if len(tokens) == 0 || subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(want)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid or missing token")
+	}
+	return nil
+}
+
+func unaryAuthInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := tokenAuthInterceptor(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := tokenAuthInterceptor(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// StartGRPCServer starts the gRPC API exposing every Controller operation on
+// grpcAddr. token is the bearer token clients must present in the
+// "authorization" metadata.
+func StartGRPCServer(grpcAddr, token string) error {
+	authToken = token
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", grpcAddr, err)
+	}
+
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryAuthInterceptor),
+		grpc.StreamInterceptor(streamAuthInterceptor),
+	)
+	igopherrpc.RegisterIGopherServer(srv, newGRPCServer(controller))
+
+	go func() {
+		logrus.Infof("gRPC API listening on %s", grpcAddr)
+		if err := srv.Serve(lis); err != nil {
+			logrus.Errorf("gRPC server stopped: %v", err)
+		}
+	}()
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/asticode/go-astilectron"
 	"github.com/go-playground/validator/v10"
@@ -23,6 +26,11 @@ var (
 	reloadCh, hotReloadCh, exitedCh chan bool
 	ctx                             context.Context
 	cancel                          context.CancelFunc
+
+	// controller is the single Controller instance shared by the Electron
+	// callbacks below and any other transport (gRPC, REST gateway, ...)
+	// registered on the process.
+	controller = NewController()
 )
 
 // MessageOut represents a message for electron (going out)
@@ -46,6 +54,8 @@ func sendMessageToElectron(msg MessageOut, callback func(m *astilectron.EventMes
 
 // Handling function for incoming messages
 func handleMessages() {
+	startGRPCAPI()
+
 	w.OnMessage(func(m *astilectron.EventMessage) interface{} {
 		// Unmarshal
 		var i MessageIn
@@ -82,17 +92,38 @@ func handleMessages() {
 		case "dmUserScrappingSettingsForm":
 			return i.dmScrapperFormCallback()
 
+		case "validateForm":
+			return i.validateFormCallback()
+
+		case "previewTemplate":
+			return i.previewTemplateCallback()
+
 		case "launchDmBot":
 			return i.launchDmBotCallback()
 
+		case "enqueueJob":
+			return i.enqueueJobCallback()
+
+		case "listJobs":
+			return i.listJobsCallback()
+
+		case "cancelJob":
+			return i.cancelJobCallback()
+
+		case "getJobHistory":
+			return i.getJobHistoryCallback()
+
 		case "stopDmBot":
 			return i.stopDmBotCallback()
 
 		case "hotReloadBot":
 			return i.hotReloadCallback()
 
-		case "getLogs":
-			return i.getLogsCallback()
+		case "subscribeLogs":
+			return i.subscribeLogsCallback()
+
+		case "unsubscribeLogs":
+			return i.unsubscribeLogsCallback()
 
 		default:
 			logrus.Error("Unexpected message received.")
@@ -101,184 +132,286 @@ func handleMessages() {
 	})
 }
 
+// startGRPCAPI starts the gRPC API alongside the Electron window so IGopher
+// can be driven headlessly through the same Controller the Electron
+// callbacks below use. It only logs on failure instead of aborting startup,
+// since the Electron GUI itself doesn't depend on it.
+func startGRPCAPI() {
+	token := os.Getenv("IGOPHER_API_TOKEN")
+	if token == "" {
+		logrus.Warning("IGOPHER_API_TOKEN isn't set, the gRPC API won't start.")
+		return
+	}
+	addr := os.Getenv("IGOPHER_GRPC_ADDR")
+	if addr == "" {
+		addr = "127.0.0.1:50051"
+	}
+	if err := StartGRPCServer(addr, token); err != nil {
+		logrus.Errorf("Failed to start gRPC API: %v", err)
+	}
+}
+
 /* Callback functiosn to handle electron messages */
 
 func (m *MessageIn) resetGlobalSettingsCallback() MessageOut {
-	config = ResetBotConfig()
-	ExportConfig(config)
+	if err := controller.ResetConfig(context.Background()); err != nil {
+		return MessageOut{Status: ERROR, Msg: fmt.Sprintf("Global configuration reset failed! Error: %v", err)}
+	}
 	return MessageOut{Status: SUCCESS, Msg: "Global configuration was successfully reseted!"}
 }
 
 func (m *MessageIn) clearDataCallback() MessageOut {
-	if err := ClearData(); err != nil {
+	if err := controller.ClearData(context.Background()); err != nil {
 		return MessageOut{Status: ERROR, Msg: fmt.Sprintf("IGopher data clearing failed! Error: %v", err)}
 	}
 	return MessageOut{Status: SUCCESS, Msg: "IGopher data successfully cleared!"}
 }
 
 func (m *MessageIn) credentialsFormCallback() MessageOut {
-	var err error
 	var credentialsConfig AccountYaml
 	// Unmarshal payload
-	if err = json.Unmarshal([]byte(m.Payload), &credentialsConfig); err != nil {
+	if err := json.Unmarshal([]byte(m.Payload), &credentialsConfig); err != nil {
 		logrus.Errorf("Failed to unmarshal message payload: %v", err)
 		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
 	}
 
-	err = validate.Struct(credentialsConfig)
-	if err != nil {
+	if err := controller.UpdateCredentials(context.Background(), credentialsConfig); err != nil {
 		logrus.Warning("Validation issue on credentials form, abort.")
-		return MessageOut{Status: ERROR, Msg: "Validation issue on credentials form, please check given informations."}
+		return MessageOut{Status: ERROR, Msg: "Validation issue on credentials form, please check given informations.", Payload: fieldErrors(credentialsConfig, err)}
 	}
-
-	config.Account = credentialsConfig
-	ExportConfig(config)
 	return MessageOut{Status: SUCCESS, Msg: "Credentials settings successfully updated!"}
 }
 
 func (m *MessageIn) quotasFormCallback() MessageOut {
-	var err error
 	var quotasConfig QuotasYaml
 	// Unmarshal payload
-	if err = json.Unmarshal([]byte(m.Payload), &quotasConfig); err != nil {
+	if err := json.Unmarshal([]byte(m.Payload), &quotasConfig); err != nil {
 		logrus.Errorf("Failed to unmarshal message payload: %v", err)
 		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
 	}
 
-	err = validate.Struct(quotasConfig)
-	if err != nil {
+	if err := controller.UpdateQuotas(context.Background(), quotasConfig); err != nil {
 		logrus.Warning("Validation issue on quotas form, abort.")
-		return MessageOut{Status: ERROR, Msg: "Validation issue on quotas form, please check given informations."}
+		return MessageOut{Status: ERROR, Msg: "Validation issue on quotas form, please check given informations.", Payload: fieldErrors(quotasConfig, err)}
 	}
-
-	config.Quotas = quotasConfig
-	ExportConfig(config)
 	return MessageOut{Status: SUCCESS, Msg: "Quotas settings successfully updated!"}
 }
 
 func (m *MessageIn) schedulerCallback() MessageOut {
-	var err error
 	var schedulerConfig ScheduleYaml
 	// Unmarshal payload
-	if err = json.Unmarshal([]byte(m.Payload), &schedulerConfig); err != nil {
+	if err := json.Unmarshal([]byte(m.Payload), &schedulerConfig); err != nil {
 		logrus.Errorf("Failed to unmarshal message payload: %v", err)
 		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
 	}
 
-	err = validate.Struct(schedulerConfig)
-	if err != nil {
+	if err := controller.UpdateSchedule(context.Background(), schedulerConfig); err != nil {
 		logrus.Warning("Validation issue on scheduler form, abort.")
-		return MessageOut{Status: ERROR, Msg: "Validation issue on scheduler form, please check given informations."}
+		return MessageOut{Status: ERROR, Msg: "Validation issue on scheduler form, please check given informations.", Payload: fieldErrors(schedulerConfig, err)}
 	}
-
-	config.Schedule = schedulerConfig
-	ExportConfig(config)
 	return MessageOut{Status: SUCCESS, Msg: "Scheduler settings successfully updated!"}
 }
 
 func (m *MessageIn) blacklistFormCallback() MessageOut {
-	var err error
 	var blacklistConfig BlacklistYaml
 	// Unmarshal payload
-	if err = json.Unmarshal([]byte(m.Payload), &blacklistConfig); err != nil {
+	if err := json.Unmarshal([]byte(m.Payload), &blacklistConfig); err != nil {
 		logrus.Errorf("Failed to unmarshal message payload: %v", err)
 		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
 	}
 
-	err = validate.Struct(blacklistConfig)
-	if err != nil {
+	if err := controller.UpdateBlacklist(context.Background(), blacklistConfig); err != nil {
 		logrus.Warning("Validation issue on blacklist form, abort.")
-		return MessageOut{Status: ERROR, Msg: "Validation issue on blacklist form, please check given informations."}
+		return MessageOut{Status: ERROR, Msg: "Validation issue on blacklist form, please check given informations.", Payload: fieldErrors(blacklistConfig, err)}
 	}
-
-	config.Blacklist = blacklistConfig
-	ExportConfig(config)
 	return MessageOut{Status: SUCCESS, Msg: "Blacklist settings successfully updated!"}
 }
 
 func (m *MessageIn) dmBotFormCallback() MessageOut {
-	var err error
 	var dmConfig AutoDmYaml
 	// Unmarshal payload
-	if err = json.Unmarshal([]byte(m.Payload), &dmConfig); err != nil {
+	if err := json.Unmarshal([]byte(m.Payload), &dmConfig); err != nil {
 		logrus.Errorf("Failed to unmarshal message payload: %v", err)
 		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
 	}
 
-	err = validate.Struct(dmConfig)
-	if err != nil {
+	if err := controller.UpdateAutoDm(context.Background(), dmConfig); err != nil {
 		logrus.Warning("Validation issue on dm tool form, abort.")
-		return MessageOut{Status: ERROR, Msg: "Validation issue on dm tool form, please check given informations."}
+		return MessageOut{Status: ERROR, Msg: "Validation issue on dm tool form, please check given informations.", Payload: fieldErrors(dmConfig, err)}
 	}
-
-	config.AutoDm = dmConfig
-	ExportConfig(config)
 	return MessageOut{Status: SUCCESS, Msg: "Dm bot settings successfully updated!"}
 }
 
+func (m *MessageIn) previewTemplateCallback() MessageOut {
+	var payload struct {
+		Template string   `json:"template"`
+		Users    []string `json:"users,omitempty"`
+		Count    int      `json:"count,omitempty"`
+	}
+	// Unmarshal payload
+	if err := json.Unmarshal([]byte(m.Payload), &payload); err != nil {
+		logrus.Errorf("Failed to unmarshal message payload: %v", err)
+		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
+	}
+
+	tpl, err := ParseMessageTemplate(payload.Template)
+	if err != nil {
+		return MessageOut{
+			Status:  ERROR,
+			Msg:     "Invalid message template, please check given informations.",
+			Payload: fieldErrors(payload, &TemplateError{Field: "template", Err: err}),
+		}
+	}
+
+	users := payload.Users
+	if len(users) == 0 {
+		users = syntheticPreviewUsers(payload.Count)
+	}
+
+	samples := make([]string, 0, len(users))
+	for _, username := range users {
+		rendered, err := tpl.Render(TemplateContext{
+			Username: username,
+			FullName: strings.Title(strings.ReplaceAll(username, "_", " ")),
+			Now:      time.Now(),
+		}, seedForRecipient("preview", username))
+		if err != nil {
+			return MessageOut{Status: ERROR, Msg: fmt.Sprintf("Failed to render template: %v", err)}
+		}
+		samples = append(samples, rendered)
+	}
+	return MessageOut{Status: SUCCESS, Msg: "Template preview successfully generated!", Payload: samples}
+}
+
 func (m *MessageIn) dmScrapperFormCallback() MessageOut {
-	var err error
 	var scrapperConfig ScrapperYaml
 	// Unmarshal payload
-	if err = json.Unmarshal([]byte(m.Payload), &scrapperConfig); err != nil {
+	if err := json.Unmarshal([]byte(m.Payload), &scrapperConfig); err != nil {
 		logrus.Errorf("Failed to unmarshal message payload: %v", err)
 		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
 	}
 
-	err = validate.Struct(scrapperConfig)
-	if err != nil {
+	if err := controller.UpdateScrapper(context.Background(), scrapperConfig); err != nil {
 		logrus.Warning("Validation issue on scrapper form, abort.")
-		return MessageOut{Status: ERROR, Msg: "Validation issue on scrapper form, please check given informations."}
+		return MessageOut{Status: ERROR, Msg: "Validation issue on scrapper form, please check given informations.", Payload: fieldErrors(scrapperConfig, err)}
 	}
-
-	config.SrcUsers = scrapperConfig
-	ExportConfig(config)
 	return MessageOut{Status: SUCCESS, Msg: "Scrapper settings successfully updated!"}
 }
 
 func (m *MessageIn) launchDmBotCallback() MessageOut {
-	var err error
-	if err = CheckConfigValidity(); err == nil {
-		ctx, cancel = context.WithCancel(context.Background())
-		go launchDmBot(ctx)
-		return MessageOut{Status: SUCCESS, Msg: "Dm bot successfully launched!"}
+	if err := controller.Launch(context.Background()); err != nil {
+		return MessageOut{Status: ERROR, Msg: err.Error()}
 	}
-	return MessageOut{Status: ERROR, Msg: err.Error()}
+	return MessageOut{Status: SUCCESS, Msg: "Dm bot successfully launched!"}
+}
+
+func (m *MessageIn) enqueueJobCallback() MessageOut {
+	var payload struct {
+		Targets []string   `json:"targets"`
+		Trigger JobTrigger `json:"trigger"`
+	}
+	// Unmarshal payload
+	if err := json.Unmarshal([]byte(m.Payload), &payload); err != nil {
+		logrus.Errorf("Failed to unmarshal message payload: %v", err)
+		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
+	}
+
+	job, err := controller.EnqueueJob(context.Background(), payload.Targets, payload.Trigger)
+	if err != nil {
+		return MessageOut{Status: ERROR, Msg: err.Error()}
+	}
+	return MessageOut{Status: SUCCESS, Msg: "Job successfully queued!", Payload: job}
+}
+
+func (m *MessageIn) listJobsCallback() MessageOut {
+	jobs, err := controller.ListJobs(context.Background())
+	if err != nil {
+		return MessageOut{Status: ERROR, Msg: err.Error()}
+	}
+	return MessageOut{Status: SUCCESS, Payload: jobs}
+}
+
+func (m *MessageIn) cancelJobCallback() MessageOut {
+	var payload struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(m.Payload), &payload); err != nil {
+		logrus.Errorf("Failed to unmarshal message payload: %v", err)
+		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
+	}
+
+	if err := controller.CancelJob(context.Background(), payload.ID); err != nil {
+		return MessageOut{Status: ERROR, Msg: err.Error()}
+	}
+	return MessageOut{Status: SUCCESS, Msg: "Job successfully canceled!"}
+}
+
+func (m *MessageIn) getJobHistoryCallback() MessageOut {
+	var payload struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(m.Payload), &payload); err != nil {
+		logrus.Errorf("Failed to unmarshal message payload: %v", err)
+		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
+	}
+
+	job, err := controller.JobHistory(context.Background(), payload.ID)
+	if err != nil {
+		return MessageOut{Status: ERROR, Msg: err.Error()}
+	}
+	return MessageOut{Status: SUCCESS, Payload: job}
 }
 
 func (m *MessageIn) stopDmBotCallback() MessageOut {
-	if exitedCh != nil {
-		cancel()
-		res := <-exitedCh
-		if res {
-			return MessageOut{Status: SUCCESS, Msg: "Dm bot successfully stopped!"}
-		}
-		return MessageOut{Status: ERROR, Msg: "Error during bot stopping! Please restart IGopher"}
+	if err := controller.Stop(context.Background()); err != nil {
+		return MessageOut{Status: ERROR, Msg: err.Error()}
 	}
-	return MessageOut{Status: ERROR, Msg: "Bot is in the initialization phase, please wait before trying to stop it."}
+	return MessageOut{Status: SUCCESS, Msg: "Dm bot successfully stopped!"}
 }
 
 func (m *MessageIn) hotReloadCallback() MessageOut {
-	if BotStruct.running {
-		if hotReloadCh != nil {
-			hotReloadCh <- true
-			res := <-hotReloadCh
-			if res {
-				return MessageOut{Status: SUCCESS, Msg: "Bot hot reload successfully!"}
-			}
-			return MessageOut{Status: ERROR, Msg: "Error during bot hot reload! Please restart the bot"}
-		}
-		return MessageOut{Status: ERROR, Msg: "Bot is in the initialization phase, please wait before trying to hot reload it."}
+	if err := controller.HotReload(context.Background()); err != nil {
+		return MessageOut{Status: ERROR, Msg: err.Error()}
 	}
-	return MessageOut{Status: ERROR, Msg: "Bot isn't running yet."}
+	return MessageOut{Status: SUCCESS, Msg: "Bot hot reload successfully!"}
 }
 
-func (m *MessageIn) getLogsCallback() MessageOut {
-	logs, err := parseLogsToString()
+// electronLogSubID tracks the single log subscription owned by the Electron
+// window, so unsubscribeLogsCallback knows which one to tear down.
+var electronLogSubID *int
+
+func (m *MessageIn) subscribeLogsCallback() MessageOut {
+	if electronLogSubID != nil {
+		return MessageOut{Status: ERROR, Msg: "Already subscribed to logs."}
+	}
+
+	var payload struct {
+		Level string `json:"level"`
+	}
+	// The level filter is optional, default to Info.
+	_ = json.Unmarshal([]byte(m.Payload), &payload)
+	level, err := logrus.ParseLevel(payload.Level)
 	if err != nil {
-		logrus.Errorf("Can't parse logs: %v", err)
-		return MessageOut{Status: ERROR, Msg: fmt.Sprintf("Can't parse logs: %v", err)}
+		level = logrus.InfoLevel
+	}
+
+	id, lines := hub.Subscribe(level)
+	electronLogSubID = &id
+
+	go func() {
+		for line := range lines {
+			sendMessageToElectron(MessageOut{Status: SUCCESS, Msg: "logLine", Payload: line}, nil)
+		}
+	}()
+
+	return MessageOut{Status: SUCCESS, Msg: "Subscribed to logs!"}
+}
+
+func (m *MessageIn) unsubscribeLogsCallback() MessageOut {
+	if electronLogSubID == nil {
+		return MessageOut{Status: ERROR, Msg: "Not subscribed to logs."}
 	}
-	logrus.Debug("Logs fetched successfully!")
-	return MessageOut{Status: SUCCESS, Msg: logs}
+	hub.Unsubscribe(*electronLogSubID)
+	electronLogSubID = nil
+	return MessageOut{Status: SUCCESS, Msg: "Unsubscribed from logs!"}
 }
\ No newline at end of file
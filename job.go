@@ -0,0 +1,58 @@
+package igopher
+
+import "time"
+
+// JobStatus is the lifecycle state of a Job.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// JobTrigger decides when a Job runs. A zero value means "run now". A
+// non-empty Cron makes the job recurring; RunAt schedules a single future
+// run instead.
+type JobTrigger struct {
+	Cron  string     `json:"cron,omitempty"`
+	RunAt *time.Time `json:"runAt,omitempty"`
+}
+
+// TargetResult records the outcome of a DM attempt against a single
+// recipient, so a user can inspect exactly what happened during an
+// overnight campaign.
+type TargetResult struct {
+	Username string    `json:"username"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+	SentAt   time.Time `json:"sentAt"`
+}
+
+// Job is one queued or completed DM campaign: a BotConfigYaml snapshot, its
+// target user list, its trigger, and - once it has run - its outcome.
+type Job struct {
+	ID      string        `json:"id"`
+	Config  BotConfigYaml `json:"config"`
+	Targets []string      `json:"targets,omitempty"`
+	Trigger JobTrigger    `json:"trigger"`
+	// ScheduleID is set on the run records spawned by a recurring job
+	// (Trigger.Cron), pointing back at the Job ID the Cron was registered
+	// on. It is empty for that original recurring definition itself and
+	// for plain one-shot jobs.
+	ScheduleID string         `json:"scheduleId,omitempty"`
+	Status     JobStatus      `json:"status"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	StartedAt  *time.Time     `json:"startedAt,omitempty"`
+	EndedAt    *time.Time     `json:"endedAt,omitempty"`
+	Results    []TargetResult `json:"results,omitempty"`
+	Error      string         `json:"error,omitempty"`
+
+	// startedCh is closed by JobQueue.run once the job's Status is actually
+	// JobRunning (and ctx/cancel are its own), so Enqueue can wait for that
+	// before reporting success. It is unexported and always nil on a job
+	// loaded back from the store: only a freshly submitted job needs it.
+	startedCh chan struct{}
+}
@@ -0,0 +1,391 @@
+package igopher
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// jobsDBPath is where queued/completed jobs are persisted.
+const jobsDBPath = "jobs.db"
+
+// runQueueSize bounds how many jobs may be waiting for the worker
+// goroutine at once. It only needs to absorb a burst of Enqueue/cron-tick
+// calls landing faster than runs complete, not hold a long-term backlog.
+const runQueueSize = 64
+
+// JobQueue enqueues DM campaigns and runs them one at a time against the
+// shared bot lifecycle (package-level ctx/cancel/exitedCh), since IGopher
+// only ever drives a single Instagram browser session at once. A single
+// worker goroutine drains runCh so launchDmBot is never started for two
+// jobs at once, however many callers hit Enqueue or cron ticks fire
+// concurrently. Each job's state and outcome is persisted to store so it
+// survives a restart.
+type JobQueue struct {
+	mu          sync.Mutex
+	store       *jobStore
+	jobs        map[string]*Job
+	cancels     map[string]context.CancelFunc
+	cronEntries map[string]cron.EntryID
+	sched       *cron.Cron
+	runCh       chan *Job
+}
+
+// NewJobQueue opens (or creates) the job store at path, restores any
+// previously queued/completed jobs, and starts the worker goroutine that
+// runs them one at a time.
+func NewJobQueue(path string) (*JobQueue, error) {
+	store, err := newJobStore(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job store: %w", err)
+	}
+	jobs, err := store.loadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jobs: %w", err)
+	}
+
+	q := &JobQueue{
+		store:       store,
+		jobs:        jobs,
+		cancels:     make(map[string]context.CancelFunc),
+		cronEntries: make(map[string]cron.EntryID),
+		sched:       cron.New(),
+		runCh:       make(chan *Job, runQueueSize),
+	}
+	q.sched.Start()
+	go q.worker()
+	q.rehydrate()
+	return q, nil
+}
+
+// rehydrate re-arms everything NewJobQueue just loaded from store: recurring
+// definitions (Trigger.Cron, ScheduleID empty) get their cron entry
+// re-registered, and pending one-shot/RunAt jobs are resubmitted so they
+// still run after a restart instead of sitting at "queued" forever. A job
+// that was JobRunning when the process stopped can't still be running now,
+// so it's marked failed instead.
+func (q *JobQueue) rehydrate() {
+	q.mu.Lock()
+	pending := make([]*Job, 0, len(q.jobs))
+	var recurring []*Job
+	for _, job := range q.jobs {
+		switch job.Status {
+		case JobRunning:
+			job.Status = JobFailed
+			job.Error = "interrupted by a restart"
+			ended := time.Now()
+			job.EndedAt = &ended
+			pending = append(pending, job)
+		case JobQueued:
+			if job.Trigger.Cron != "" && job.ScheduleID == "" {
+				recurring = append(recurring, job)
+			} else {
+				pending = append(pending, job)
+			}
+		}
+	}
+	q.mu.Unlock()
+
+	// armCron takes q.mu itself, so it only runs once the lock is released.
+	for _, job := range recurring {
+		q.armCron(job)
+	}
+
+	for _, job := range pending {
+		job := job
+		switch {
+		case job.Status == JobFailed:
+			q.save(job)
+		case job.Trigger.RunAt != nil:
+			delay := time.Until(*job.Trigger.RunAt)
+			time.AfterFunc(delay, func() { q.submit(job) })
+		default:
+			q.submit(job)
+		}
+	}
+}
+
+// worker runs queued jobs one at a time, in submission order, so
+// launchDmBot never gets started for a second job while one is still
+// running.
+func (q *JobQueue) worker() {
+	for job := range q.runCh {
+		q.run(job)
+	}
+}
+
+// startTimeout bounds how long Enqueue waits for an immediately-triggered
+// job to actually reach JobRunning before giving up and returning it anyway.
+// It only guards against a stuck worker; a healthy one starts a job almost
+// instantly.
+const startTimeout = 10 * time.Second
+
+// Enqueue records a new Job for config/targets and schedules it: one-shot
+// jobs run as soon as the worker is free (or at Trigger.RunAt), recurring
+// jobs spawn a fresh run record on every Trigger.Cron tick. For an
+// immediate job (the zero trigger), Enqueue waits for it to actually reach
+// JobRunning before returning, so a caller that calls Stop/HotReload right
+// after never races against the package-level ctx/cancel of a previous run.
+func (q *JobQueue) Enqueue(config BotConfigYaml, targets []string, trigger JobTrigger) (*Job, error) {
+	job := &Job{
+		ID:        newJobID(),
+		Config:    config,
+		Targets:   targets,
+		Trigger:   trigger,
+		Status:    JobQueued,
+		CreatedAt: time.Now(),
+	}
+	if trigger == (JobTrigger{}) {
+		job.startedCh = make(chan struct{})
+	}
+
+	q.mu.Lock()
+	q.jobs[job.ID] = job
+	q.mu.Unlock()
+	if err := q.store.save(job); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case trigger.Cron != "":
+		if err := q.armCron(job); err != nil {
+			return nil, err
+		}
+	case trigger.RunAt != nil:
+		delay := time.Until(*trigger.RunAt)
+		time.AfterFunc(delay, func() { q.submit(job) })
+	default:
+		q.submit(job)
+		select {
+		case <-job.startedCh:
+		case <-time.After(startTimeout):
+		}
+	}
+	return job, nil
+}
+
+// armCron registers definition's Trigger.Cron with the scheduler and
+// remembers its cron.EntryID so Cancel can later deregister it.
+func (q *JobQueue) armCron(definition *Job) error {
+	id, err := q.sched.AddFunc(definition.Trigger.Cron, func() { q.submitRun(definition) })
+	if err != nil {
+		return fmt.Errorf("invalid cron expression: %w", err)
+	}
+	q.mu.Lock()
+	q.cronEntries[definition.ID] = id
+	q.mu.Unlock()
+	return nil
+}
+
+// submit hands job to the worker goroutine. It never blocks the caller for
+// long: runCh is sized for a reasonable burst, and Enqueue/cron ticks don't
+// need to wait for a run slot to free up.
+func (q *JobQueue) submit(job *Job) {
+	q.runCh <- job
+}
+
+// submitRun fires on every cron tick of a recurring job: it clones a fresh
+// run record off definition (its own ID, its own CreatedAt/Results/...) so
+// each execution keeps its own history entry, instead of every tick
+// overwriting the status/timestamps of a single shared Job.
+func (q *JobQueue) submitRun(definition *Job) {
+	run := &Job{
+		ID:         newJobID(),
+		Config:     definition.Config,
+		Targets:    definition.Targets,
+		Trigger:    definition.Trigger,
+		ScheduleID: definition.ID,
+		Status:     JobQueued,
+		CreatedAt:  time.Now(),
+	}
+
+	q.mu.Lock()
+	q.jobs[run.ID] = run
+	q.mu.Unlock()
+	if err := q.save(run); err != nil {
+		logrus.Errorf("Failed to persist scheduled run of job %s, skipping it: %v", definition.ID, err)
+		return
+	}
+	q.submit(run)
+}
+
+// run executes job against the shared bot lifecycle and records its
+// outcome. It is only ever called from worker, so jobs never run
+// concurrently: launchDmBot drives a single browser session at a time.
+func (q *JobQueue) run(job *Job) {
+	q.mu.Lock()
+	if job.Status == JobCanceled {
+		q.mu.Unlock()
+		return
+	}
+	config = job.Config
+	runCtx, runCancel := context.WithCancel(context.Background())
+	ctx, cancel = runCtx, runCancel
+	q.cancels[job.ID] = runCancel
+	job.Status = JobRunning
+	started := time.Now()
+	job.StartedAt = &started
+	if job.startedCh != nil {
+		close(job.startedCh)
+	}
+	q.mu.Unlock()
+	q.save(job)
+
+	// Render each target's greeting once up front (deterministically, by
+	// job ID + username) and hand it to launchDmBot through
+	// preparedMessages, so real campaigns get the same spintax/placeholder
+	// expansion previewTemplateCallback already previews.
+	rendered, failedRenders, renderErr := renderJobMessages(job.ID, job.Config.AutoDm.Greetings, job.Targets)
+	if renderErr != nil {
+		logrus.Errorf("Job %s: failed to render a greeting for one or more targets: %v", job.ID, renderErr)
+	}
+	preparedMessages = rendered
+
+	if exitedCh == nil {
+		exitedCh = make(chan bool)
+	}
+	go launchDmBot(ctx)
+	success := <-exitedCh
+	preparedMessages = nil
+
+	q.mu.Lock()
+	ended := time.Now()
+	job.EndedAt = &ended
+	delete(q.cancels, job.ID)
+	switch {
+	case job.Status == JobCanceled:
+		// Already marked by Cancel.
+	case success:
+		job.Status = JobSucceeded
+	default:
+		job.Status = JobFailed
+		job.Error = "bot exited with an error, check logs for details"
+	}
+	job.Results = resultsForTargets(job.Targets, failedRenders, job.Status == JobSucceeded, job.Error, ended)
+	q.mu.Unlock()
+	q.save(job)
+}
+
+// resultsForTargets builds one TargetResult per target. A target whose
+// greeting failed to render (present in failedRenders) is always reported
+// as failed, with its own render error, regardless of whether the rest of
+// the run succeeded; everyone else shares the run's overall outcome, since
+// launchDmBot only reports a single pass/fail for the whole run today.
+func resultsForTargets(targets []string, failedRenders map[string]error, success bool, errMsg string, at time.Time) []TargetResult {
+	if len(targets) == 0 {
+		return nil
+	}
+	results := make([]TargetResult, len(targets))
+	for i, username := range targets {
+		if renderErr, failed := failedRenders[username]; failed {
+			results[i] = TargetResult{Username: username, Success: false, Error: renderErr.Error(), SentAt: at}
+			continue
+		}
+		results[i] = TargetResult{Username: username, Success: success, Error: errMsg, SentAt: at}
+	}
+	return results
+}
+
+// Cancel stops job id if it is queued or running. If id is a recurring
+// definition (Trigger.Cron), its cron entry is deregistered so it stops
+// spawning new runs; already-spawned runs are unaffected.
+func (q *JobQueue) Cancel(id string) error {
+	q.mu.Lock()
+	job, ok := q.jobs[id]
+	if !ok {
+		q.mu.Unlock()
+		return fmt.Errorf("job %s not found", id)
+	}
+	if job.Status != JobQueued && job.Status != JobRunning {
+		q.mu.Unlock()
+		return fmt.Errorf("job %s already %s", id, job.Status)
+	}
+
+	job.Status = JobCanceled
+	cancelFn := q.cancels[id]
+	entryID, hasCronEntry := q.cronEntries[id]
+	if hasCronEntry {
+		delete(q.cronEntries, id)
+	}
+	q.mu.Unlock()
+
+	if hasCronEntry {
+		q.sched.Remove(entryID)
+	}
+	if cancelFn != nil {
+		cancelFn()
+	}
+	return q.save(job)
+}
+
+// List returns every known job, most recently created first.
+func (q *JobQueue) List() []*Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs
+}
+
+// Get returns job id and whether it was found.
+func (q *JobQueue) Get(id string) (*Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, ok := q.jobs[id]
+	return job, ok
+}
+
+func (q *JobQueue) save(job *Job) error {
+	if err := q.store.save(job); err != nil {
+		logrus.Errorf("Failed to persist job %s: %v", job.ID, err)
+		return err
+	}
+	return nil
+}
+
+// Reset wipes every persisted and in-memory job, deleting the store's
+// underlying file on disk (used by Controller.ClearData, alongside the rest
+// of IGopher's persisted data).
+func (q *JobQueue) Reset() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	path := q.store.path
+	if err := q.store.Close(); err != nil {
+		return fmt.Errorf("failed to close job store: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove job store: %w", err)
+	}
+
+	store, err := newJobStore(path)
+	if err != nil {
+		return fmt.Errorf("failed to reopen job store: %w", err)
+	}
+	for _, entryID := range q.cronEntries {
+		q.sched.Remove(entryID)
+	}
+	q.store = store
+	q.jobs = make(map[string]*Job)
+	q.cancels = make(map[string]context.CancelFunc)
+	q.cronEntries = make(map[string]cron.EntryID)
+	return nil
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("job-%d-%s", time.Now().UnixNano(), hex.EncodeToString(b))
+}
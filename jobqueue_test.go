@@ -0,0 +1,171 @@
+package igopher
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var errRenderFailedForTest = errors.New("template: bad template")
+
+func newTestJobQueue(t *testing.T) *JobQueue {
+	t.Helper()
+	q, err := NewJobQueue(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %v", err)
+	}
+	return q
+}
+
+func TestJobQueueCancelQueuedJob(t *testing.T) {
+	q := newTestJobQueue(t)
+
+	job := &Job{ID: "job-1", Status: JobQueued, CreatedAt: time.Now()}
+	q.mu.Lock()
+	q.jobs[job.ID] = job
+	q.mu.Unlock()
+
+	if err := q.Cancel(job.ID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	got, ok := q.Get(job.ID)
+	if !ok {
+		t.Fatalf("Get() didn't find job %s", job.ID)
+	}
+	if got.Status != JobCanceled {
+		t.Errorf("Status = %s, want %s", got.Status, JobCanceled)
+	}
+}
+
+func TestJobQueueCancelUnknownJob(t *testing.T) {
+	q := newTestJobQueue(t)
+	if err := q.Cancel("does-not-exist"); err == nil {
+		t.Error("Cancel() on an unknown job should return an error")
+	}
+}
+
+func TestJobQueueListOrdersMostRecentFirst(t *testing.T) {
+	q := newTestJobQueue(t)
+
+	older := &Job{ID: "job-older", Status: JobQueued, CreatedAt: time.Now().Add(-time.Hour)}
+	newer := &Job{ID: "job-newer", Status: JobQueued, CreatedAt: time.Now()}
+	q.mu.Lock()
+	q.jobs[older.ID] = older
+	q.jobs[newer.ID] = newer
+	q.mu.Unlock()
+
+	jobs := q.List()
+	if len(jobs) != 2 || jobs[0].ID != newer.ID || jobs[1].ID != older.ID {
+		t.Errorf("List() = %v, want [%s, %s]", jobs, newer.ID, older.ID)
+	}
+}
+
+func TestJobQueueResetClearsJobsAndPersistsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	q, err := NewJobQueue(path)
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %v", err)
+	}
+
+	job := &Job{ID: "job-1", Status: JobQueued, CreatedAt: time.Now()}
+	q.mu.Lock()
+	q.jobs[job.ID] = job
+	q.mu.Unlock()
+	if err := q.save(job); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	if err := q.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if jobs := q.List(); len(jobs) != 0 {
+		t.Errorf("List() after Reset() = %v, want empty", jobs)
+	}
+
+	// Release q's lock on the bolt file, or reopening the same path blocks.
+	if err := q.store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	reopened, err := NewJobQueue(path)
+	if err != nil {
+		t.Fatalf("NewJobQueue() after Reset() error = %v", err)
+	}
+	if jobs := reopened.List(); len(jobs) != 0 {
+		t.Errorf("List() on reopened store = %v, want empty", jobs)
+	}
+}
+
+func TestResultsForTargetsSharesOutcomeAcrossTargets(t *testing.T) {
+	at := time.Now()
+	results := resultsForTargets([]string{"alice", "bob"}, nil, true, "", at)
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	for _, r := range results {
+		if !r.Success || !r.SentAt.Equal(at) {
+			t.Errorf("result %+v doesn't match the job outcome", r)
+		}
+	}
+}
+
+func TestResultsForTargetsReportsRenderFailureRegardlessOfOutcome(t *testing.T) {
+	at := time.Now()
+	failed := map[string]error{"bob": errRenderFailedForTest}
+	results := resultsForTargets([]string{"alice", "bob"}, failed, true, "", at)
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	if !results[0].Success {
+		t.Errorf("alice should share the job's successful outcome, got %+v", results[0])
+	}
+	if results[1].Success || results[1].Error == "" {
+		t.Errorf("bob's failed render should be reported, got %+v", results[1])
+	}
+}
+
+func TestResultsForTargetsEmpty(t *testing.T) {
+	if results := resultsForTargets(nil, nil, true, "", time.Now()); results != nil {
+		t.Errorf("resultsForTargets(nil, ...) = %v, want nil", results)
+	}
+}
+
+func TestJobQueueCancelRemovesCronEntry(t *testing.T) {
+	q := newTestJobQueue(t)
+
+	job, err := q.Enqueue(BotConfigYaml{}, nil, JobTrigger{Cron: "0 0 1 1 *"})
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	if _, ok := q.cronEntries[job.ID]; !ok {
+		t.Fatal("Enqueue() with a cron trigger didn't register a cron entry")
+	}
+
+	if err := q.Cancel(job.ID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if _, ok := q.cronEntries[job.ID]; ok {
+		t.Error("Cancel() should deregister the job's cron entry")
+	}
+	if entries := q.sched.Entries(); len(entries) != 0 {
+		t.Errorf("scheduler still holds entries after Cancel(): %+v", entries)
+	}
+}
+
+func TestJobQueueEnqueueWaitsForImmediateJobToStart(t *testing.T) {
+	q := newTestJobQueue(t)
+
+	job, err := q.Enqueue(BotConfigYaml{}, nil, JobTrigger{})
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	got, ok := q.Get(job.ID)
+	if !ok {
+		t.Fatalf("Get() didn't find job %s", job.ID)
+	}
+	if got.Status == JobQueued {
+		t.Error("Enqueue() of an immediate job returned before it started running")
+	}
+}
@@ -0,0 +1,68 @@
+package igopher
+
+import (
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// jobsBucket is the single BoltDB bucket jobs are stored under, keyed by
+// Job.ID.
+var jobsBucket = []byte("jobs")
+
+// jobStore persists Job records to a local BoltDB file so queued/completed
+// campaigns survive an IGopher restart.
+type jobStore struct {
+	db   *bolt.DB
+	path string
+}
+
+func newJobStore(path string) (*jobStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(jobsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &jobStore{db: db, path: path}, nil
+}
+
+// save upserts job, keyed by its ID.
+func (s *jobStore) save(job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Put([]byte(job.ID), data)
+	})
+}
+
+// loadAll reads every persisted Job back, keyed by ID.
+func (s *jobStore) loadAll() (map[string]*Job, error) {
+	jobs := make(map[string]*Job)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).ForEach(func(k, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			jobs[job.ID] = &job
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (s *jobStore) Close() error {
+	return s.db.Close()
+}
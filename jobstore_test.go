@@ -0,0 +1,32 @@
+package igopher
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJobStoreSaveAndLoadAll(t *testing.T) {
+	store, err := newJobStore(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("newJobStore() error = %v", err)
+	}
+	defer store.Close()
+
+	job := &Job{ID: "job-1", Status: JobQueued, CreatedAt: time.Now().Truncate(time.Second)}
+	if err := store.save(job); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	jobs, err := store.loadAll()
+	if err != nil {
+		t.Fatalf("loadAll() error = %v", err)
+	}
+	got, ok := jobs[job.ID]
+	if !ok {
+		t.Fatalf("loadAll() didn't return job %s", job.ID)
+	}
+	if got.Status != job.Status || !got.CreatedAt.Equal(job.CreatedAt) {
+		t.Errorf("loadAll() = %+v, want %+v", got, job)
+	}
+}
@@ -0,0 +1,118 @@
+package igopher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogLine is a single formatted log record pushed to subscribers, carrying
+// enough structure for the UI to render colored, filterable output without
+// having to re-parse a flat string.
+type LogLine struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Level     logrus.Level `json:"level"`
+	Module    string       `json:"module"`
+	Phase     string       `json:"phase,omitempty"`
+	Message   string       `json:"message"`
+}
+
+// logSubscriber receives every LogLine accepted by its level filter through
+// a bounded ring buffer, so a slow consumer (a stalled Electron window, a
+// gRPC client that stopped reading) can never block the logger.
+type logSubscriber struct {
+	ch    chan LogLine
+	level logrus.Level
+}
+
+// logHub fans log records produced by logHook out to every subscriber
+// registered through Subscribe.
+type logHub struct {
+	mu          sync.Mutex
+	subscribers map[int]*logSubscriber
+	nextID      int
+}
+
+var hub = &logHub{subscribers: make(map[int]*logSubscriber)}
+
+// ringBufferSize bounds how many unread LogLines a subscriber may queue up
+// before the oldest entries are dropped to make room for new ones.
+const ringBufferSize = 256
+
+// Subscribe registers a new subscriber accepting records at level and
+// above, and returns its id (to later Unsubscribe) along with the channel
+// to read LogLines from.
+func (h *logHub) Subscribe(level logrus.Level) (int, <-chan LogLine) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	sub := &logSubscriber{ch: make(chan LogLine, ringBufferSize), level: level}
+	h.subscribers[id] = sub
+	return id, sub.ch
+}
+
+// Unsubscribe deregisters a subscriber and closes its channel.
+func (h *logHub) Unsubscribe(id int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sub, ok := h.subscribers[id]; ok {
+		close(sub.ch)
+		delete(h.subscribers, id)
+	}
+}
+
+// publish fans out line to every subscriber whose level filter accepts it,
+// dropping the oldest queued line instead of blocking if a subscriber's
+// ring buffer is full.
+func (h *logHub) publish(line LogLine) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, sub := range h.subscribers {
+		if line.Level > sub.level {
+			continue
+		}
+		select {
+		case sub.ch <- line:
+		default:
+			select {
+			case <-sub.ch:
+			default:
+			}
+			select {
+			case sub.ch <- line:
+			default:
+			}
+		}
+	}
+}
+
+// logHook is a logrus.Hook that pushes every formatted log record to hub so
+// subscribers (Electron window, gRPC stream, WebSocket) receive it in real
+// time instead of having to poll/parse the log file.
+type logHook struct{}
+
+func (logHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (logHook) Fire(entry *logrus.Entry) error {
+	module, _ := entry.Data["module"].(string)
+	phase, _ := entry.Data["phase"].(string)
+	hub.publish(LogLine{
+		Timestamp: entry.Time,
+		Level:     entry.Level,
+		Module:    module,
+		Phase:     phase,
+		Message:   entry.Message,
+	})
+	return nil
+}
+
+func init() {
+	logrus.AddHook(logHook{})
+}
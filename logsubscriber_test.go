@@ -0,0 +1,64 @@
+package igopher
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLogHubPublishRespectsLevelFilter(t *testing.T) {
+	h := &logHub{subscribers: make(map[int]*logSubscriber)}
+	id, lines := h.Subscribe(logrus.WarnLevel)
+	defer h.Unsubscribe(id)
+
+	h.publish(LogLine{Level: logrus.InfoLevel, Message: "too verbose"})
+	h.publish(LogLine{Level: logrus.ErrorLevel, Message: "accepted"})
+
+	select {
+	case line := <-lines:
+		if line.Message != "accepted" {
+			t.Errorf("got %q, want the Error-level line to win, not the Info one", line.Message)
+		}
+	default:
+		t.Fatal("expected the Error-level line to be published")
+	}
+
+	select {
+	case line := <-lines:
+		t.Errorf("unexpected extra line %+v, the Info-level one should have been filtered out", line)
+	default:
+	}
+}
+
+func TestLogHubPublishDropsOldestWhenSubscriberBufferIsFull(t *testing.T) {
+	h := &logHub{subscribers: make(map[int]*logSubscriber)}
+	id, lines := h.Subscribe(logrus.InfoLevel)
+	defer h.Unsubscribe(id)
+
+	for i := 0; i < ringBufferSize+1; i++ {
+		h.publish(LogLine{Level: logrus.InfoLevel, Message: "line"})
+	}
+
+	count := 0
+	for {
+		select {
+		case <-lines:
+			count++
+		default:
+			if count != ringBufferSize {
+				t.Errorf("buffered lines = %d, want %d", count, ringBufferSize)
+			}
+			return
+		}
+	}
+}
+
+func TestLogHubUnsubscribeClosesChannel(t *testing.T) {
+	h := &logHub{subscribers: make(map[int]*logSubscriber)}
+	id, lines := h.Subscribe(logrus.InfoLevel)
+	h.Unsubscribe(id)
+
+	if _, ok := <-lines; ok {
+		t.Error("channel should be closed after Unsubscribe")
+	}
+}
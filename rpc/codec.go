@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec lets the message types in this package travel over gRPC without
+// a real protoc-gen-go codegen step: it marshals/unmarshals them as JSON
+// instead of the protobuf wire format, which is fine since nothing here
+// implements proto.Message. Registering it under the "proto" name (the
+// codec grpc-go picks when a call doesn't ask for another content-subtype)
+// makes it the effective default for this server and its clients.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "proto"
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
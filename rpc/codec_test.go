@@ -0,0 +1,27 @@
+package rpc
+
+import "testing"
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	c := jsonCodec{}
+	want := &StatusReply{Success: true, Message: "ok"}
+
+	data, err := c.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got StatusReply
+	if err := c.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got != *want {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+}
+
+func TestJSONCodecName(t *testing.T) {
+	if name := (jsonCodec{}).Name(); name != "proto" {
+		t.Errorf("Name() = %q, want %q", name, "proto")
+	}
+}
@@ -0,0 +1,78 @@
+// igopher.proto describes this service, but these message types are
+// hand-maintained, not protoc-generated: they travel over the wire through
+// jsonCodec (see codec.go), not the protobuf binary format, so there is no
+// ProtoReflect()/Reset()/String() to implement here. Keep them in sync with
+// igopher.proto by hand until a real protoc-gen-go toolchain is wired in.
+
+package rpc
+
+// Empty carries no data.
+type Empty struct{}
+
+// JSONRequest wraps a JSON-encoded payload, mirroring MessageIn.Payload.
+type JSONRequest struct {
+	Payload []byte `json:"payload,omitempty"`
+}
+
+func (r *JSONRequest) GetPayload() []byte {
+	if r != nil {
+		return r.Payload
+	}
+	return nil
+}
+
+// StatusReply mirrors MessageOut without the Electron-specific fields.
+type StatusReply struct {
+	Success bool   `json:"success,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (r *StatusReply) GetSuccess() bool {
+	if r != nil {
+		return r.Success
+	}
+	return false
+}
+
+func (r *StatusReply) GetMessage() string {
+	if r != nil {
+		return r.Message
+	}
+	return ""
+}
+
+// LogLine is a single formatted log record, see logsubscriber.go.
+type LogLine struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	Level     string `json:"level,omitempty"`
+	Module    string `json:"module,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+func (l *LogLine) GetTimestamp() string {
+	if l != nil {
+		return l.Timestamp
+	}
+	return ""
+}
+
+func (l *LogLine) GetLevel() string {
+	if l != nil {
+		return l.Level
+	}
+	return ""
+}
+
+func (l *LogLine) GetModule() string {
+	if l != nil {
+		return l.Module
+	}
+	return ""
+}
+
+func (l *LogLine) GetMessage() string {
+	if l != nil {
+		return l.Message
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+// igopher.proto describes this service, but this file is a hand-maintained
+// mirror of what protoc-gen-go-grpc would produce, not its actual output
+// (see the note at the top of igopher.pb.go). Keep it in sync by hand.
+
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IGopherServer is the server API for the IGopher service, implemented by
+// grpc_server.go against the shared Controller.
+type IGopherServer interface {
+	UpdateCredentials(context.Context, *JSONRequest) (*StatusReply, error)
+	UpdateQuotas(context.Context, *JSONRequest) (*StatusReply, error)
+	UpdateSchedule(context.Context, *JSONRequest) (*StatusReply, error)
+	UpdateBlacklist(context.Context, *JSONRequest) (*StatusReply, error)
+	UpdateAutoDm(context.Context, *JSONRequest) (*StatusReply, error)
+	UpdateScrapper(context.Context, *JSONRequest) (*StatusReply, error)
+	Launch(context.Context, *Empty) (*StatusReply, error)
+	Stop(context.Context, *Empty) (*StatusReply, error)
+	HotReload(context.Context, *Empty) (*StatusReply, error)
+	StreamLogs(*Empty, IGopher_StreamLogsServer) error
+	ResetConfig(context.Context, *Empty) (*StatusReply, error)
+	ClearData(context.Context, *Empty) (*StatusReply, error)
+}
+
+// UnimplementedIGopherServer can be embedded for forward compatibility with
+// future RPCs added to the service.
+type UnimplementedIGopherServer struct{}
+
+func (UnimplementedIGopherServer) UpdateCredentials(context.Context, *JSONRequest) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCredentials not implemented")
+}
+func (UnimplementedIGopherServer) UpdateQuotas(context.Context, *JSONRequest) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQuotas not implemented")
+}
+func (UnimplementedIGopherServer) UpdateSchedule(context.Context, *JSONRequest) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSchedule not implemented")
+}
+func (UnimplementedIGopherServer) UpdateBlacklist(context.Context, *JSONRequest) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlacklist not implemented")
+}
+func (UnimplementedIGopherServer) UpdateAutoDm(context.Context, *JSONRequest) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAutoDm not implemented")
+}
+func (UnimplementedIGopherServer) UpdateScrapper(context.Context, *JSONRequest) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScrapper not implemented")
+}
+func (UnimplementedIGopherServer) Launch(context.Context, *Empty) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Launch not implemented")
+}
+func (UnimplementedIGopherServer) Stop(context.Context, *Empty) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedIGopherServer) HotReload(context.Context, *Empty) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HotReload not implemented")
+}
+func (UnimplementedIGopherServer) StreamLogs(*Empty, IGopher_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedIGopherServer) ResetConfig(context.Context, *Empty) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetConfig not implemented")
+}
+func (UnimplementedIGopherServer) ClearData(context.Context, *Empty) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearData not implemented")
+}
+
+// IGopher_StreamLogsServer is the server-side stream handle for StreamLogs.
+type IGopher_StreamLogsServer interface {
+	Send(*LogLine) error
+	grpc.ServerStream
+}
+
+type igopherStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *igopherStreamLogsServer) Send(m *LogLine) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// _IGopher_StreamLogs_Handler adapts IGopherServer.StreamLogs to
+// grpc.StreamDesc.Handler, the same way protoc-gen-go-grpc's generated
+// handler would.
+func _IGopher_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IGopherServer).StreamLogs(m, &igopherStreamLogsServer{stream})
+}
+
+// RegisterIGopherServer registers srv on s, as protoc-gen-go-grpc would.
+func RegisterIGopherServer(s grpc.ServiceRegistrar, srv IGopherServer) {
+	s.RegisterService(&IGopher_ServiceDesc, srv)
+}
+
+// IGopher_ServiceDesc is the grpc.ServiceDesc for the IGopher service.
+var IGopher_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "igopher.rpc.IGopher",
+	HandlerType: (*IGopherServer)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _IGopher_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "igopher.proto",
+}
@@ -0,0 +1,154 @@
+package igopher
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateContext exposes the placeholders available to a DM template body,
+// e.g. "Hey {{.FullName}}, it's {{.Now.Format "15:04"}}!".
+type TemplateContext struct {
+	Username string
+	FullName string
+	Now      time.Time
+}
+
+// spintaxPattern matches a single {a|b|c} alternation. It requires at least
+// one '|', so it leaves plain "{{.Field}}" actions alone; an action that
+// contains a '|' pipeline ("{{.Name | printf ...}}") is matched as an
+// alternation too.
+var spintaxPattern = regexp.MustCompile(`\{([^{}]+(?:\|[^{}]+)+)\}`)
+
+// expandSpintax resolves every {a|b|c} alternation in s using rng, picking
+// one alternative uniformly at random. It runs until no alternation is left
+// so nested spintax ({a|{b|c}}) resolves from the inside out.
+func expandSpintax(s string, rng *rand.Rand) string {
+	for spintaxPattern.MatchString(s) {
+		s = spintaxPattern.ReplaceAllStringFunc(s, func(match string) string {
+			options := strings.Split(match[1:len(match)-1], "|")
+			return options[rng.Intn(len(options))]
+		})
+	}
+	return s
+}
+
+// seedForRecipient derives a deterministic RNG seed from scope (typically a
+// job ID) and username, so spintax alternation stays stable across re-runs
+// of the same job for the same recipient instead of changing every time.
+func seedForRecipient(scope, username string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(scope + "|" + username))
+	return int64(h.Sum64())
+}
+
+// TemplateError reports a template parse failure for a specific form field,
+// so it can be surfaced as a FieldValidationError (see validation.go).
+type TemplateError struct {
+	Field string
+	Err   error
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("invalid template in %s: %v", e.Field, e.Err)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// MessageTemplate is a DM body combining spintax alternation with Go
+// text/template placeholders.
+type MessageTemplate struct {
+	src string
+}
+
+// ParseMessageTemplate validates that tplSrc is a well-formed template,
+// without resolving spintax yet (spintax is expanded per-recipient in
+// Render, seeded from that recipient, not at parse time).
+func ParseMessageTemplate(tplSrc string) (*MessageTemplate, error) {
+	if _, err := template.New("dm").Parse(tplSrc); err != nil {
+		return nil, err
+	}
+	return &MessageTemplate{src: tplSrc}, nil
+}
+
+// syntheticPreviewUsers fabricates count placeholder usernames for
+// previewTemplateCallback when the caller didn't supply real scraped users
+// to preview against.
+func syntheticPreviewUsers(count int) []string {
+	if count <= 0 {
+		count = 3
+	}
+	users := make([]string, count)
+	for i := range users {
+		users[i] = fmt.Sprintf("preview_user_%d", i+1)
+	}
+	return users
+}
+
+// Render expands spintax deterministically for seed, then executes the
+// text/template against ctx.
+func (t *MessageTemplate) Render(ctx TemplateContext, seed int64) (string, error) {
+	expanded := expandSpintax(t.src, rand.New(rand.NewSource(seed)))
+
+	tpl, err := template.New("dm").Parse(expanded)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, ctx); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// preparedMessages holds the fully rendered (spintax + text/template
+// expanded) DM body for each recipient of the job currently running, keyed
+// by username. It is populated by renderJobMessages before launchDmBot runs
+// and consumed there.
+var preparedMessages map[string]string
+
+// renderJobMessages renders one message per target in targets, picking a
+// deterministic greeting (by seedForRecipient(scope, username)) among
+// greetings and expanding it. It renders every target even after a failure
+// so a single bad recipient (e.g. one whose picked greeting fails to parse
+// or execute) doesn't block the rest of the campaign. failed lists the targets whose
+// greeting could not be rendered (and is empty when greetings is empty -
+// that's "nothing configured yet", not a per-recipient failure). err is the
+// first error encountered, if any.
+func renderJobMessages(scope string, greetings []string, targets []string) (messages map[string]string, failed map[string]error, err error) {
+	messages = make(map[string]string, len(targets))
+	failed = make(map[string]error)
+	if len(greetings) == 0 {
+		return messages, failed, nil
+	}
+
+	for _, username := range targets {
+		seed := seedForRecipient(scope, username)
+		greeting := greetings[int(uint64(seed)%uint64(len(greetings)))]
+
+		rendered, renderErr := func() (string, error) {
+			tpl, parseErr := ParseMessageTemplate(greeting)
+			if parseErr != nil {
+				return "", parseErr
+			}
+			return tpl.Render(TemplateContext{Username: username, FullName: username, Now: time.Now()}, seed)
+		}()
+		if renderErr != nil {
+			wrapped := fmt.Errorf("recipient %s: %w", username, renderErr)
+			failed[username] = wrapped
+			if err == nil {
+				err = wrapped
+			}
+			continue
+		}
+		messages[username] = rendered
+	}
+	return messages, failed, err
+}
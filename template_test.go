@@ -0,0 +1,81 @@
+package igopher
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestExpandSpintaxIsDeterministicForTheSameSeed(t *testing.T) {
+	const src = "Hey {there|friend|buddy}!"
+	a := expandSpintax(src, rand.New(rand.NewSource(42)))
+	b := expandSpintax(src, rand.New(rand.NewSource(42)))
+	if a != b {
+		t.Errorf("expandSpintax() with the same seed = %q, %q, want equal", a, b)
+	}
+}
+
+func TestExpandSpintaxDoesNotTouchTemplateActions(t *testing.T) {
+	const src = "Hey {{.Username}}!"
+	got := expandSpintax(src, rand.New(rand.NewSource(1)))
+	if got != src {
+		t.Errorf("expandSpintax() = %q, want unchanged %q", got, src)
+	}
+}
+
+func TestMessageTemplateRender(t *testing.T) {
+	tpl, err := ParseMessageTemplate("Hey {{.Username}}!")
+	if err != nil {
+		t.Fatalf("ParseMessageTemplate() error = %v", err)
+	}
+	got, err := tpl.Render(TemplateContext{Username: "alice"}, 0)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "Hey alice!"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestParseMessageTemplateRejectsMalformedTemplate(t *testing.T) {
+	if _, err := ParseMessageTemplate("Hey {{.Username!"); err == nil {
+		t.Error("ParseMessageTemplate() should reject an unterminated action")
+	}
+}
+
+func TestRenderJobMessagesRendersEveryTarget(t *testing.T) {
+	greetings := []string{"Hi {{.Username}}!"}
+	messages, failed, err := renderJobMessages("job-1", greetings, []string{"alice", "bob"})
+	if err != nil {
+		t.Fatalf("renderJobMessages() error = %v", err)
+	}
+	if len(failed) != 0 {
+		t.Errorf("failed = %v, want none", failed)
+	}
+	if messages["alice"] != "Hi alice!" || messages["bob"] != "Hi bob!" {
+		t.Errorf("messages = %v", messages)
+	}
+}
+
+func TestRenderJobMessagesNoGreetingsConfigured(t *testing.T) {
+	messages, failed, err := renderJobMessages("job-1", nil, []string{"alice"})
+	if err != nil {
+		t.Fatalf("renderJobMessages() error = %v", err)
+	}
+	if len(messages) != 0 || len(failed) != 0 {
+		t.Errorf("renderJobMessages() with no greetings = %v, %v, want both empty", messages, failed)
+	}
+}
+
+func TestRenderJobMessagesReportsFailureButKeepsGoing(t *testing.T) {
+	greetings := []string{"Hey {{.Username!"}
+	messages, failed, err := renderJobMessages("job-1", greetings, []string{"alice", "bob"})
+	if err == nil {
+		t.Fatal("renderJobMessages() should report the malformed template")
+	}
+	if len(messages) != 0 {
+		t.Errorf("messages = %v, want none", messages)
+	}
+	if len(failed) != 2 {
+		t.Errorf("failed = %v, want an entry for both targets", failed)
+	}
+}
@@ -0,0 +1,128 @@
+package igopher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// FieldValidationError describes a single failed validator.FieldError in a
+// shape the front-end can map straight onto the offending form input,
+// instead of a single generic "Validation issue ..." string.
+type FieldValidationError struct {
+	// Field is the JSON tag path of the offending field (e.g. "username"),
+	// not the Go struct field name, so it matches what the Electron forms
+	// actually render.
+	Field   string      `json:"field"`
+	Tag     string      `json:"tag"`
+	Param   string      `json:"param,omitempty"`
+	Value   interface{} `json:"value,omitempty"`
+	Message string      `json:"message"`
+}
+
+// fieldErrors maps err (expected to wrap validator.ValidationErrors, as
+// returned by validate.Struct(form)) to the per-field errors the Electron
+// forms use to highlight the exact offending input. A *TemplateError is
+// mapped to a single entry for its Field, with tag "template". It returns nil
+// if err carries neither.
+func fieldErrors(form interface{}, err error) []FieldValidationError {
+	var valErrs validator.ValidationErrors
+	if errors.As(err, &valErrs) {
+		fieldErrs := make([]FieldValidationError, 0, len(valErrs))
+		for _, fe := range valErrs {
+			fieldErrs = append(fieldErrs, FieldValidationError{
+				Field:   jsonFieldName(form, fe.StructField()),
+				Tag:     fe.Tag(),
+				Param:   fe.Param(),
+				Value:   fe.Value(),
+				Message: fe.Error(),
+			})
+		}
+		return fieldErrs
+	}
+
+	var tplErr *TemplateError
+	if errors.As(err, &tplErr) {
+		return []FieldValidationError{{
+			Field:   tplErr.Field,
+			Tag:     "template",
+			Message: tplErr.Err.Error(),
+		}}
+	}
+	return nil
+}
+
+// jsonFieldName returns the JSON tag name of structField on form, falling
+// back to the Go field name itself when there is no `json` tag (or it is
+// "-").
+func jsonFieldName(form interface{}, structField string) string {
+	t := reflect.TypeOf(form)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	field, ok := t.FieldByName(structField)
+	if !ok {
+		return structField
+	}
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	if tag == "" || tag == "-" {
+		return structField
+	}
+	return tag
+}
+
+// newFormValue returns a fresh zero value for the Electron form identified
+// by its message name (the same names used in handleMessages' switch), or
+// false if it doesn't match any known form.
+func newFormValue(form string) (interface{}, bool) {
+	switch form {
+	case "igCredentialsForm":
+		return &AccountYaml{}, true
+	case "quotasForm":
+		return &QuotasYaml{}, true
+	case "schedulerForm":
+		return &ScheduleYaml{}, true
+	case "blacklistForm":
+		return &BlacklistYaml{}, true
+	case "dmSettingsForm":
+		return &AutoDmYaml{}, true
+	case "dmUserScrappingSettingsForm":
+		return &ScrapperYaml{}, true
+	default:
+		return nil, false
+	}
+}
+
+// validateFormCallback lets the Electron UI validate a form as the user
+// types, without persisting anything. It runs the same validator checks as
+// the *FormCallback handlers and returns their per-field errors on failure;
+// the dm settings form's greeting templates are not parsed here.
+func (m *MessageIn) validateFormCallback() MessageOut {
+	var req struct {
+		Form    string          `json:"form"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(m.Payload, &req); err != nil {
+		logrus.Errorf("Failed to unmarshal message payload: %v", err)
+		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal message payload."}
+	}
+
+	form, ok := newFormValue(req.Form)
+	if !ok {
+		return MessageOut{Status: ERROR, Msg: fmt.Sprintf("Unknown form %q.", req.Form)}
+	}
+	if err := json.Unmarshal(req.Payload, form); err != nil {
+		logrus.Errorf("Failed to unmarshal form payload: %v", err)
+		return MessageOut{Status: ERROR, Msg: "Failed to unmarshal form payload."}
+	}
+
+	if err := validate.Struct(form); err != nil {
+		return MessageOut{Status: ERROR, Msg: "Validation issue, please check given informations.", Payload: fieldErrors(form, err)}
+	}
+	return MessageOut{Status: SUCCESS, Msg: "Form is valid."}
+}
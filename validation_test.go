@@ -0,0 +1,65 @@
+package igopher
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type testForm struct {
+	Username string `json:"username" validate:"required"`
+}
+
+func TestFieldErrorsMapsValidatorFieldErrors(t *testing.T) {
+	form := testForm{}
+	err := validate.Struct(form)
+	if err == nil {
+		t.Fatal("validate.Struct() on an empty required field should fail")
+	}
+
+	fieldErrs := fieldErrors(form, err)
+	if len(fieldErrs) != 1 {
+		t.Fatalf("len(fieldErrors) = %d, want 1", len(fieldErrs))
+	}
+	if fieldErrs[0].Field != "username" {
+		t.Errorf("Field = %q, want %q", fieldErrs[0].Field, "username")
+	}
+	if fieldErrs[0].Tag != "required" {
+		t.Errorf("Tag = %q, want %q", fieldErrs[0].Tag, "required")
+	}
+}
+
+func TestFieldErrorsMapsTemplateError(t *testing.T) {
+	err := &TemplateError{Field: "greetings[0]", Err: errors.New("bad template")}
+	fieldErrs := fieldErrors(testForm{}, err)
+	if len(fieldErrs) != 1 {
+		t.Fatalf("len(fieldErrors) = %d, want 1", len(fieldErrs))
+	}
+	if fieldErrs[0].Field != "greetings[0]" || fieldErrs[0].Tag != "template" {
+		t.Errorf("fieldErrors = %+v", fieldErrs[0])
+	}
+}
+
+func TestFieldErrorsUnrelatedErrorReturnsNil(t *testing.T) {
+	if got := fieldErrors(testForm{}, fmt.Errorf("unrelated failure")); got != nil {
+		t.Errorf("fieldErrors() = %v, want nil", got)
+	}
+}
+
+func TestJSONFieldNameFallsBackToStructFieldWithoutJSONTag(t *testing.T) {
+	type noTagForm struct {
+		Username string
+	}
+	if got := jsonFieldName(noTagForm{}, "Username"); got != "Username" {
+		t.Errorf("jsonFieldName() = %q, want %q", got, "Username")
+	}
+}
+
+func TestNewFormValueKnownAndUnknownForms(t *testing.T) {
+	if _, ok := newFormValue("igCredentialsForm"); !ok {
+		t.Error("newFormValue(\"igCredentialsForm\") should be known")
+	}
+	if _, ok := newFormValue("doesNotExist"); ok {
+		t.Error("newFormValue(\"doesNotExist\") should be unknown")
+	}
+}